@@ -0,0 +1,209 @@
+package complete
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// testEntry is a minimal Entry for exercising AsyncValues streaming.
+type testEntry string
+
+func (e testEntry) Title() string       { return string(e) }
+func (e testEntry) Description() string { return "" }
+
+// fakeAsyncValues is a test-controlled AsyncValues: updates sent on its
+// updates channel are relayed to the channel returned by Updates,
+// honoring ctx cancellation the way a real implementation must.
+type fakeAsyncValues struct {
+	numCats int
+	updates chan CategoryUpdate
+
+	ctx context.Context
+}
+
+func newFakeAsyncValues(numCats int) *fakeAsyncValues {
+	return &fakeAsyncValues{numCats: numCats, updates: make(chan CategoryUpdate)}
+}
+
+func (f *fakeAsyncValues) NumCategories() int { return f.numCats }
+
+func (f *fakeAsyncValues) CategoryTitle(catIdx int) string {
+	return fmt.Sprintf("cat-%d", catIdx)
+}
+
+func (f *fakeAsyncValues) Updates(ctx context.Context) <-chan CategoryUpdate {
+	f.ctx = ctx
+	out := make(chan CategoryUpdate)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case u, ok := <-f.updates:
+				if !ok {
+					return
+				}
+				select {
+				case out <- u:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// waitForUpdate drives m's async loop for a single update, failing the
+// test if none arrives within a generous timeout.
+func waitForUpdate(t *testing.T, m *Model) categoryUpdatedMsg {
+	t.Helper()
+	cmd := m.waitForCategoryUpdate()
+	if cmd == nil {
+		t.Fatal("waitForCategoryUpdate returned a nil cmd")
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	select {
+	case msg := <-done:
+		cu, ok := msg.(categoryUpdatedMsg)
+		if !ok {
+			t.Fatalf("expected categoryUpdatedMsg, got %T", msg)
+		}
+		return cu
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for category update")
+		return categoryUpdatedMsg{}
+	}
+}
+
+func TestSetAsyncValues_OutOfOrderCategoryCompletion(t *testing.T) {
+	m := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	av := newFakeAsyncValues(2)
+	m.SetAsyncValues(ctx, av)
+
+	// Category 1 finishes before category 0 even sends anything.
+	go func() {
+		av.updates <- CategoryUpdate{CatIdx: 1, Entries: []Entry{testEntry("b0")}, Done: true}
+	}()
+	m.Update(waitForUpdate(t, &m))
+
+	if !m.categoryDone[1] {
+		t.Fatal("category 1 should be done")
+	}
+	if m.categoryDone[0] {
+		t.Fatal("category 0 should still be pending")
+	}
+	if !m.anyCategoryPending() {
+		t.Fatal("category 0 hasn't completed yet, so a category should still be pending")
+	}
+
+	go func() {
+		av.updates <- CategoryUpdate{CatIdx: 0, Entries: []Entry{testEntry("a0")}, Done: true}
+	}()
+	m.Update(waitForUpdate(t, &m))
+
+	if !m.categoryDone[0] || !m.categoryDone[1] {
+		t.Fatal("both categories should be done")
+	}
+	if m.anyCategoryPending() {
+		t.Fatal("no category should still be pending")
+	}
+	if len(m.listItems[0]) != 1 || m.listItems[0][0].(candidateItem).Title() != "a0" {
+		t.Fatalf("category 0 entries = %v", m.listItems[0])
+	}
+	if len(m.listItems[1]) != 1 || m.listItems[1][0].(candidateItem).Title() != "b0" {
+		t.Fatalf("category 1 entries = %v", m.listItems[1])
+	}
+}
+
+func TestSetAsyncValues_EmptyCategory(t *testing.T) {
+	m := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	av := newFakeAsyncValues(2)
+	m.SetAsyncValues(ctx, av)
+
+	// Category 0 completes with zero entries.
+	go func() { av.updates <- CategoryUpdate{CatIdx: 0, Done: true} }()
+	m.Update(waitForUpdate(t, &m))
+
+	if !m.categoryDone[0] {
+		t.Fatal("empty category 0 should still be marked done")
+	}
+	if len(m.listItems[0]) != 0 {
+		t.Fatalf("category 0 should have no entries, got %v", m.listItems[0])
+	}
+	if m.valueLists[0].SelectedItem() != nil {
+		t.Fatal("an empty category's list should have no selected item")
+	}
+
+	go func() {
+		av.updates <- CategoryUpdate{CatIdx: 1, Entries: []Entry{testEntry("c0")}, Done: true}
+	}()
+	m.Update(waitForUpdate(t, &m))
+
+	if m.anyCategoryPending() {
+		t.Fatal("no category should still be pending")
+	}
+}
+
+func TestSetAsyncValues_CancellationMidStream(t *testing.T) {
+	m := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	av := newFakeAsyncValues(2)
+	m.SetAsyncValues(ctx, av)
+
+	// Category 0 sends a partial (non-final) update; category 1 never
+	// sends anything before cancellation.
+	go func() {
+		av.updates <- CategoryUpdate{CatIdx: 0, Entries: []Entry{testEntry("a0")}}
+	}()
+	m.Update(waitForUpdate(t, &m))
+
+	if m.categoryDone[0] {
+		t.Fatal("category 0 hasn't sent Done yet")
+	}
+
+	// Mirror the real bubbletea loop: a waitForCategoryUpdate pump is
+	// already in flight (blocked on the stream) when Abort cancels the
+	// context out from under it.
+	cmd := m.waitForCategoryUpdate()
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if av.ctx.Err() == nil {
+		t.Fatal("expected the stream's context to be canceled on Abort")
+	}
+
+	// The fake closes its channel once ctx is done; the resulting
+	// "closed" update must mark every remaining category done so their
+	// spinners stop instead of ticking forever.
+	select {
+	case msg := <-done:
+		m.Update(msg)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the stream to close after cancellation")
+	}
+
+	if m.anyCategoryPending() {
+		t.Fatal("no category should be pending after the stream closes")
+	}
+	for i, done := range m.categoryDone {
+		if !done {
+			t.Fatalf("category %d should be marked done after stream closed", i)
+		}
+	}
+}
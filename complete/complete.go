@@ -1,19 +1,29 @@
 package complete
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/paginator"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 	rw "github.com/mattn/go-runewidth"
 	"github.com/muesli/reflow/truncate"
 )
 
+// stdListHeight is the initial height given to each category's
+// list.Model; the real height is set right after via SetHeight.
+const stdListHeight = 10
+
 // Values is the interface to the values displayed by the completion
 // bubble.
 type Values interface {
@@ -39,6 +49,20 @@ type Entry interface {
 	Description() string
 }
 
+// RichEntry is an optional extension of Entry for candidates with a
+// longer, Markdown-formatted description. When the currently selected
+// Entry also implements RichEntry, pressing KeyMap.ToggleDescription
+// expands a scrollable viewport rendering LongDescription via glamour in
+// place of the single-line Description.
+type RichEntry interface {
+	Entry
+
+	// LongDescription returns a Markdown-formatted extended
+	// description, rendered through glamour into the description
+	// viewport.
+	LongDescription() string
+}
+
 // Styles contain style definitions for the completions component.
 type Styles struct {
 	FocusedTitleBar             lipgloss.Style
@@ -58,6 +82,12 @@ type Styles struct {
 	DividerDot                  lipgloss.Style
 	PlaceholderDescription      lipgloss.Style
 	Description                 lipgloss.Style
+
+	// SelectedPrefix and UnselectedPrefix are drawn to the left of each
+	// item while MultiSelect is enabled, marking which candidates are
+	// part of the accepted set.
+	SelectedPrefix   string
+	UnselectedPrefix string
 }
 
 // DefaultStyles returns a set of default style definitions for the
@@ -89,6 +119,8 @@ var DefaultStyles = func() (c Styles) {
 	c.DividerDot = lipgloss.NewStyle()
 	c.Description = lipgloss.NewStyle().Foreground(chalkGray).PaddingLeft(2)
 	c.PlaceholderDescription = lipgloss.NewStyle().Foreground(chalkGray)
+	c.SelectedPrefix = "✓ "
+	c.UnselectedPrefix = "  "
 
 	return c
 }()
@@ -96,10 +128,36 @@ var DefaultStyles = func() (c Styles) {
 // KeyMap defines keybindings for navigating the completions.
 type KeyMap struct {
 	list.KeyMap
-	NextCompletions  key.Binding
-	PrevCompletions  key.Binding
-	AcceptCompletion key.Binding
-	Abort            key.Binding
+	NextCompletions   key.Binding
+	PrevCompletions   key.Binding
+	AcceptCompletion  key.Binding
+	Abort             key.Binding
+	ToggleDescription key.Binding
+	DescUp            key.Binding
+	DescDown          key.Binding
+
+	// ToggleMark marks/unmarks the highlighted entry while MultiSelect
+	// is enabled. SetMultiSelect binds it to "tab" (freeing that key
+	// from CursorDown) and unbinds it again when disabled.
+	ToggleMark key.Binding
+	MarkAll    key.Binding
+	UnmarkAll  key.Binding
+
+	// Search opens the in-list search prompt (distinct from Filter: it
+	// highlights matches across categories without removing
+	// non-matching entries). SearchNext/SearchPrev cycle through
+	// matches while searching.
+	//
+	// Deliberate deviation from the originally requested "n"/"shift+n"
+	// bindings: the search query is live-edited in the same prompt
+	// that "n"/"N" would need to cycle, so binding cycling to those
+	// letters would make it impossible to type a query containing
+	// them (e.g. "conn", "name"). SearchNext/SearchPrev are bound to
+	// the arrow keys and ctrl+n/ctrl+p instead, leaving "n"/"N" free
+	// for the query text.
+	Search     key.Binding
+	SearchNext key.Binding
+	SearchPrev key.Binding
 }
 
 // DefaultKeyMap is the default set of key bindings.
@@ -118,10 +176,19 @@ var DefaultKeyMap = KeyMap{
 		ShowFullHelp:         key.NewBinding(key.WithKeys("alt+?"), key.WithHelp("M-?", "toggle key help")),
 		CloseFullHelp:        key.NewBinding(key.WithKeys("alt+?"), key.WithHelp("M-?", "toggle key help")),
 	},
-	NextCompletions:  key.NewBinding(key.WithKeys("right", "alt+n"), key.WithHelp("→/M-n", "next column")),
-	PrevCompletions:  key.NewBinding(key.WithKeys("left", "alt+p"), key.WithHelp("←/M-p", "prev column")),
-	AcceptCompletion: key.NewBinding(key.WithKeys("enter", "ctrl+j"), key.WithHelp("C-j/enter/tab", "accept")),
-	Abort:            key.NewBinding(key.WithKeys("ctrl+c", "esc"), key.WithHelp("C-c/esc", "close/cancel")),
+	NextCompletions:   key.NewBinding(key.WithKeys("right", "alt+n"), key.WithHelp("→/M-n", "next column")),
+	PrevCompletions:   key.NewBinding(key.WithKeys("left", "alt+p"), key.WithHelp("←/M-p", "prev column")),
+	AcceptCompletion:  key.NewBinding(key.WithKeys("enter", "ctrl+j"), key.WithHelp("C-j/enter/tab", "accept")),
+	Abort:             key.NewBinding(key.WithKeys("ctrl+c", "esc"), key.WithHelp("C-c/esc", "close/cancel")),
+	ToggleDescription: key.NewBinding(key.WithKeys("alt+d"), key.WithHelp("M-d", "toggle description")),
+	DescUp:            key.NewBinding(key.WithKeys("alt+j"), key.WithHelp("M-j", "scroll description up")),
+	DescDown:          key.NewBinding(key.WithKeys("alt+k"), key.WithHelp("M-k", "scroll description down")),
+	ToggleMark:        key.NewBinding(key.WithKeys(), key.WithHelp("tab", "mark/unmark")),
+	MarkAll:           key.NewBinding(key.WithKeys("alt+a"), key.WithHelp("M-a", "mark all")),
+	UnmarkAll:         key.NewBinding(key.WithKeys("alt+u"), key.WithHelp("M-u", "unmark all")),
+	Search:            key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("C-s", "search")),
+	SearchNext:        key.NewBinding(key.WithKeys("down", "ctrl+n"), key.WithHelp("C-n/↓", "next match")),
+	SearchPrev:        key.NewBinding(key.WithKeys("up", "ctrl+p"), key.WithHelp("C-p/↑", "prev match")),
 }
 
 // Model is the model that implements the completion
@@ -138,6 +205,25 @@ type Model struct {
 	// AcceptedValue is the result of the selection.
 	AcceptedValue Entry
 
+	// Matcher is the fuzzy-matching algorithm used when filtering.
+	// Defaults to a FuzzyMatcher modeled on fzf's v2 scoring; set via
+	// SetMatcher to use exact-match, prefix-only, or a custom scorer.
+	Matcher Matcher
+
+	// Tiebreak controls the order used to resolve equally-scored
+	// matches while filtering. Valid entries are "length" (shorter
+	// match span first), "start" (earlier match start first), and
+	// "index" (original order first); entries are tried in order until
+	// one yields a difference. Defaults to []string{"length", "start", "index"}.
+	Tiebreak []string
+
+	// Searcher overrides the match function used by in-list search
+	// (ctrl+s), which is distinct from Matcher's fuzzy filter: it
+	// highlights matches across every category without removing
+	// non-matching entries. Defaults to DefaultSearcher, a
+	// case-insensitive substring match against Title.
+	Searcher Searcher
+
 	width     int
 	height    int
 	maxHeight int
@@ -149,6 +235,55 @@ type Model struct {
 	listItems     [][]list.Item
 	valueLists    []*list.Model
 	categoryNames []string
+	itemWidths    []int
+
+	filtering    bool
+	filterInput  textinput.Model
+	matchResults [][]MatchResult
+
+	searching         bool
+	searchInput       textinput.Model
+	searchMatches     []markKey
+	searchPositions   map[markKey][]int
+	searchCurrent     int
+	searchRestoreList int
+	searchRestoreSel  []int
+
+	// categoryDone tracks, per category, whether an active AsyncValues
+	// stream has finished sending entries. Non-nil only while streaming
+	// via SetAsyncValues.
+	categoryDone []bool
+	spinner      spinner.Model
+	asyncCancel  context.CancelFunc
+	asyncUpdates <-chan CategoryUpdate
+
+	descExpanded  bool
+	descViewport  viewport.Model
+	descCache     map[descCacheKey]string
+	descLastEntry Entry
+
+	// multiSelectMax is the MultiSelect cap: 0 disables multi-select,
+	// -1 means unlimited, >0 caps the marked set at that size.
+	multiSelectMax int
+	markedSet      map[markKey]bool
+	markedOrder    []markKey
+}
+
+// markKey identifies a marked candidate by its stable position: the
+// category it belongs to, and its index within that category's
+// unfiltered entry list.
+type markKey struct {
+	catIdx   int
+	entryIdx int
+}
+
+// descCacheKey identifies a rendered-markdown cache entry: a given
+// RichEntry rendered at a given viewport width. Entry values are
+// expected to be comparable (typically pointer-backed), matching the
+// existing convention of passing Entry around as an interface value.
+type descCacheKey struct {
+	entry RichEntry
+	width int
 }
 
 func (m *Model) Debug() string {
@@ -167,13 +302,241 @@ var _ tea.Model = (*Model)(nil)
 
 func New() Model {
 	return Model{
-		KeyMap:  DefaultKeyMap,
-		Styles:  DefaultStyles,
-		focused: true,
+		KeyMap:       DefaultKeyMap,
+		Styles:       DefaultStyles,
+		Matcher:      NewFuzzyMatcher(),
+		Tiebreak:     []string{"length", "start", "index"},
+		focused:      true,
+		descViewport: viewport.New(0, 0),
+	}
+}
+
+// SetMatcher overrides the fuzzy-matching algorithm used while
+// filtering. Re-runs the current filter, if any, against the new
+// matcher.
+func (m *Model) SetMatcher(matcher Matcher) {
+	m.Matcher = matcher
+	if m.filtering {
+		m.applyFilter()
+	}
+}
+
+// toggleDescription expands or collapses the rich Markdown description
+// pane for the currently selected entry.
+func (m *Model) toggleDescription() {
+	if !m.descExpanded && !m.hasRichSelection() {
+		return
+	}
+	m.descExpanded = !m.descExpanded
+	m.SetHeight(m.height)
+	if m.descExpanded {
+		// Force the upcoming syncDescViewport to treat this as a fresh
+		// entry, so expanding always starts scrolled to the top.
+		m.descLastEntry = nil
+		m.syncDescViewport()
+	}
+}
+
+// hasRichSelection reports whether the currently selected entry
+// implements RichEntry, i.e. whether there's anything for
+// ToggleDescription to expand.
+func (m *Model) hasRichSelection() bool {
+	if len(m.valueLists) == 0 {
+		return false
+	}
+	sel := m.valueLists[m.selectedList].SelectedItem()
+	if sel == nil {
+		return false
+	}
+	_, ok := sel.(candidateItem).Entry.(RichEntry)
+	return ok
+}
+
+// syncDescViewport refreshes the description viewport's content for the
+// currently selected entry. It only jumps the viewport back to the top
+// when the selected entry has actually changed, so scrolling via
+// DescUp/DescDown isn't immediately undone by the next Update's resync.
+func (m *Model) syncDescViewport() {
+	var content string
+	var entry Entry
+	if len(m.valueLists) > 0 {
+		if sel := m.valueLists[m.selectedList].SelectedItem(); sel != nil {
+			entry = sel.(candidateItem).Entry
+			if rich, ok := entry.(RichEntry); ok {
+				content = m.renderLongDescription(rich, m.descViewport.Width)
+			}
+		}
+	}
+	changed := entry != m.descLastEntry
+	m.descLastEntry = entry
+	m.descViewport.SetContent(content)
+	if changed {
+		m.descViewport.GotoTop()
+	}
+}
+
+// renderLongDescription renders entry's LongDescription through
+// glamour, caching the result per (entry, width) so scrolling the
+// viewport doesn't re-render.
+func (m *Model) renderLongDescription(entry RichEntry, width int) string {
+	key := descCacheKey{entry: entry, width: width}
+	if cached, ok := m.descCache[key]; ok {
+		return cached
+	}
+
+	r, err := glamour.NewTermRenderer(glamour.WithAutoStyle(), glamour.WithWordWrap(width))
+	var out string
+	if err != nil {
+		out = entry.LongDescription()
+	} else if out, err = r.Render(entry.LongDescription()); err != nil {
+		out = entry.LongDescription()
+	}
+
+	if m.descCache == nil {
+		m.descCache = make(map[descCacheKey]string)
+	}
+	m.descCache[key] = out
+	return out
+}
+
+// SetMultiSelect enables or disables multi-select mode. max is the cap
+// on the marked set: 0 disables multi-select, -1 means unlimited, and
+// any positive value caps the number of marked candidates. Disabling
+// clears any existing marks and frees ToggleMark's "tab" binding back
+// to CursorDown.
+func (m *Model) SetMultiSelect(max int) {
+	m.multiSelectMax = max
+	if max == 0 {
+		m.KeyMap.ToggleMark = key.NewBinding(key.WithKeys(), key.WithHelp("tab", "mark/unmark"))
+		m.markedSet = nil
+		m.markedOrder = nil
+	} else {
+		m.KeyMap.ToggleMark = key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "mark/unmark"))
+	}
+}
+
+// toggleMark marks or unmarks the currently highlighted entry, subject
+// to the MultiSelect cap.
+func (m *Model) toggleMark(curList *list.Model) {
+	if m.multiSelectMax == 0 {
+		return
+	}
+	sel := curList.SelectedItem()
+	if sel == nil {
+		return
+	}
+	item := sel.(candidateItem)
+	k := markKey{catIdx: item.catIdx, entryIdx: item.entryIdx}
+	if m.markedSet[k] {
+		m.unmark(k)
+		return
+	}
+	if m.multiSelectMax > 0 && len(m.markedOrder) >= m.multiSelectMax {
+		return
+	}
+	m.mark(k)
+}
+
+func (m *Model) mark(k markKey) {
+	if m.markedSet == nil {
+		m.markedSet = make(map[markKey]bool)
+	}
+	m.markedSet[k] = true
+	m.markedOrder = append(m.markedOrder, k)
+}
+
+func (m *Model) unmark(k markKey) {
+	delete(m.markedSet, k)
+	for i, o := range m.markedOrder {
+		if o == k {
+			m.markedOrder = append(m.markedOrder[:i], m.markedOrder[i+1:]...)
+			break
+		}
+	}
+}
+
+// markAll marks every candidate across every category, up to the
+// MultiSelect cap.
+func (m *Model) markAll() {
+	if m.multiSelectMax == 0 {
+		return
 	}
+	for _, items := range m.listItems {
+		for _, it := range items {
+			ci := it.(candidateItem)
+			k := markKey{catIdx: ci.catIdx, entryIdx: ci.entryIdx}
+			if m.markedSet[k] {
+				continue
+			}
+			if m.multiSelectMax > 0 && len(m.markedOrder) >= m.multiSelectMax {
+				return
+			}
+			m.mark(k)
+		}
+	}
+}
+
+// unmarkAll clears the marked set.
+func (m *Model) unmarkAll() {
+	m.markedSet = nil
+	m.markedOrder = nil
+}
+
+// markedCount returns how many entries in category catIdx are marked.
+func (m *Model) markedCount(catIdx int) int {
+	n := 0
+	for _, it := range m.listItems[catIdx] {
+		if m.markedSet[markKey{catIdx: catIdx, entryIdx: it.(candidateItem).entryIdx}] {
+			n++
+		}
+	}
+	return n
+}
+
+// acceptSelection finalizes the current selection on AcceptCompletion
+// (or AcceptWhileFiltering): in MultiSelect mode it finalizes the marked
+// set, falling back to the highlighted entry if nothing is marked;
+// otherwise it accepts the highlighted entry directly.
+func (m *Model) acceptSelection(curList *list.Model) {
+	if m.multiSelectMax != 0 {
+		if len(m.markedOrder) == 0 {
+			if sel := curList.SelectedItem(); sel != nil {
+				ci := sel.(candidateItem)
+				m.mark(markKey{catIdx: ci.catIdx, entryIdx: ci.entryIdx})
+			}
+		}
+		m.AcceptedValue = nil
+		m.Err = io.EOF
+		return
+	}
+	if sel := curList.SelectedItem(); sel != nil {
+		m.AcceptedValue = sel.(candidateItem).Entry
+		m.Err = io.EOF
+	}
+}
+
+// AcceptedValues returns the marked entries in the order they were
+// marked, for use when MultiSelect is enabled. It is empty unless
+// MultiSelect is on and something was marked or accepted.
+func (m *Model) AcceptedValues() []Entry {
+	if len(m.markedOrder) == 0 {
+		return nil
+	}
+	out := make([]Entry, len(m.markedOrder))
+	for i, k := range m.markedOrder {
+		out[i] = m.listItems[k.catIdx][k.entryIdx].(candidateItem).Entry
+	}
+	return out
 }
 
-type candidateItem struct{ Entry }
+// candidateItem wraps an Entry with its stable position (category and
+// index within that category's unfiltered entry list), so marking and
+// rendering survive filtering/re-ranking.
+type candidateItem struct {
+	Entry
+	catIdx   int
+	entryIdx int
+}
 
 var _ list.Item = candidateItem{}
 
@@ -189,7 +552,7 @@ func convertToItems(values Values, catIdx int) (res []list.Item, maxWidth int) {
 	for i := 0; i < numE; i++ {
 		it := values.Entry(catIdx, i)
 		maxWidth = max(maxWidth, rw.StringWidth(it.Title()))
-		res[i] = candidateItem{it}
+		res[i] = candidateItem{Entry: it, catIdx: catIdx, entryIdx: i}
 	}
 	return res, maxWidth
 }
@@ -197,7 +560,6 @@ func convertToItems(values Values, catIdx int) (res []list.Item, maxWidth int) {
 type renderer struct {
 	m       *Model
 	listIdx int
-	width   int
 }
 
 var _ list.ItemDelegate = (*renderer)(nil)
@@ -209,16 +571,66 @@ func (r *renderer) Render(w io.Writer, m list.Model, index int, item list.Item)
 		return
 	}
 	s := i.Title()
-	iw := rw.StringWidth(s)
-	if iw < r.width {
-		s += strings.Repeat(" ", r.width-iw)
-	}
 	st := &r.m.Styles
 	fn := st.Item.Render
 	if r.m.selectedList == r.listIdx && index == m.Index() {
 		fn = st.SelectedItem.Render
 	}
-	fmt.Fprint(w, fn(s))
+
+	mr := r.m.matchFor(r.listIdx, index)
+	if r.m.searching {
+		if positions, ok := r.m.searchPositions[markKey{catIdx: i.catIdx, entryIdx: i.entryIdx}]; ok {
+			mr.Positions = append(append([]int{}, mr.Positions...), positions...)
+		}
+	}
+	rendered := highlightMatch(s, mr, st.DefaultFilterCharacterMatch)
+	width := r.m.itemWidths[r.listIdx]
+	if iw := rw.StringWidth(s); iw < width {
+		rendered += strings.Repeat(" ", width-iw)
+	}
+	if r.m.multiSelectMax != 0 {
+		prefix := st.UnselectedPrefix
+		if r.m.markedSet[markKey{catIdx: i.catIdx, entryIdx: i.entryIdx}] {
+			prefix = st.SelectedPrefix
+		}
+		rendered = prefix + rendered
+	}
+	fmt.Fprint(w, fn(rendered))
+}
+
+// matchFor returns the MatchResult computed for the entry currently at
+// (catIdx, entryIdx) in the (possibly filtered) list, or the zero value
+// if no filter is active.
+func (m *Model) matchFor(catIdx, entryIdx int) MatchResult {
+	if catIdx >= len(m.matchResults) {
+		return MatchResult{}
+	}
+	results := m.matchResults[catIdx]
+	if entryIdx >= len(results) {
+		return MatchResult{}
+	}
+	return results[entryIdx]
+}
+
+// highlightMatch wraps the matched byte ranges of s in style, leaving
+// the rest of the string untouched.
+func highlightMatch(s string, mr MatchResult, style lipgloss.Style) string {
+	if len(mr.Positions) == 0 {
+		return s
+	}
+	matched := make(map[int]bool, len(mr.Positions))
+	for _, p := range mr.Positions {
+		matched[p] = true
+	}
+	var b strings.Builder
+	for idx, r := range s {
+		if matched[idx] {
+			b.WriteString(style.Render(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
 }
 
 // Height is part of the list.ItemDelegate interface.
@@ -245,14 +657,26 @@ func (m *Model) SetWidth(width int) {
 	for _, l := range m.valueLists {
 		l.SetWidth(listWidth)
 	}
+	m.descViewport.Width = listWidth
+	if m.descExpanded {
+		m.syncDescViewport()
+	}
 }
 
 // SetHeight changes the height.
 func (m *Model) SetHeight(height int) {
 	// Make space for the description string.
 	m.height = clamp(height, 2, m.maxHeight)
+	listHeight := m.height - 1
+	if m.descExpanded {
+		// Give the description viewport the bottom half of the box.
+		m.descViewport.Height = max(listHeight/2, 2)
+		listHeight -= m.descViewport.Height
+	} else {
+		m.descViewport.Height = 0
+	}
 	for _, l := range m.valueLists {
-		l.SetHeight(m.height - 1)
+		l.SetHeight(listHeight)
 		// Ensure paginator shows 5 items per page
 		l.Paginator.PerPage = 4
 		// Force recomputing the keybindings, which
@@ -273,30 +697,27 @@ func (m *Model) GetMaxHeight() int {
 
 // SetValues resets the values. It also recomputes the height.
 func (m *Model) SetValues(values Values) {
+	m.cancelAsync()
 	m.Err = nil
 	m.selectedList = 0
 	m.values = values
+	m.filtering = false
+	m.matchResults = nil
+	m.searching = false
+	m.searchMatches = nil
+	m.searchPositions = nil
+	m.categoryDone = nil
+	m.descExpanded = false
+	m.descCache = nil
+	m.descLastEntry = nil
+	m.markedSet = nil
+	m.markedOrder = nil
 	numCats := values.NumCategories()
 	m.valueLists = make([]*list.Model, numCats)
 	m.listItems = make([][]list.Item, numCats)
 	m.categoryNames = make([]string, numCats)
-	const stdHeight = 10
-	listDecorationRows :=
-		1 +
-			max(
-				m.Styles.FocusedTitleBar.GetVerticalPadding(),
-				m.Styles.BlurredTitleBar.GetVerticalPadding()) +
-			max(
-				m.Styles.FocusedTitleBar.GetVerticalMargins(),
-				m.Styles.BlurredTitleBar.GetVerticalMargins()) +
-			1 +
-			m.Styles.PaginationStyle.GetVerticalPadding() +
-			m.Styles.PaginationStyle.GetVerticalMargins()
-	m.maxHeight = listDecorationRows
-
-	perItemHeight := 1 + max(
-		m.Styles.Item.GetVerticalPadding(),
-		m.Styles.SelectedItem.GetVerticalPadding())
+	m.itemWidths = make([]int, numCats)
+	m.maxHeight = m.listDecorationRows()
 
 	for i := 0; i < numCats; i++ {
 		category := values.CategoryTitle(i)
@@ -307,20 +728,11 @@ func (m *Model) SetValues(values Values) {
 		if itemsMaxWidth < 10 {
 			itemsMaxWidth = 10
 		}
+		m.itemWidths[i] = itemsMaxWidth
 		// Limit to 5 items per page
 		itemsToShow := min(len(m.listItems[i]), 5)
-		m.maxHeight = max(m.maxHeight, itemsToShow*perItemHeight+listDecorationRows)
-		r := &renderer{m: m, listIdx: i, width: itemsMaxWidth}
-		l := list.New(m.listItems[i], r, itemsMaxWidth, stdHeight)
-		l.Title = "" // Don't use list's built-in title to avoid truncation
-		l.KeyMap = m.KeyMap.KeyMap
-		l.DisableQuitKeybindings()
-		l.SetShowHelp(false)
-		l.SetShowStatusBar(false)
-		// Set the paginator to show all items (up to 5) per page
-		l.Paginator.PerPage = 4
-		l.Paginator.Type = paginator.Arabic
-		m.valueLists[i] = &l
+		m.maxHeight = max(m.maxHeight, itemsToShow*m.perItemHeight()+m.listDecorationRows())
+		m.valueLists[i] = m.newCategoryList(i, m.listItems[i])
 	}
 
 	// Make space for the description.
@@ -336,12 +748,217 @@ func (m *Model) SetValues(values Values) {
 	}
 }
 
+// listDecorationRows returns the number of rows consumed by each
+// category list's title bar and pagination footer, based on the
+// current Styles.
+func (m *Model) listDecorationRows() int {
+	return 1 +
+		max(
+			m.Styles.FocusedTitleBar.GetVerticalPadding(),
+			m.Styles.BlurredTitleBar.GetVerticalPadding()) +
+		max(
+			m.Styles.FocusedTitleBar.GetVerticalMargins(),
+			m.Styles.BlurredTitleBar.GetVerticalMargins()) +
+		1 +
+		m.Styles.PaginationStyle.GetVerticalPadding() +
+		m.Styles.PaginationStyle.GetVerticalMargins()
+}
+
+// perItemHeight returns the number of rows consumed by a single
+// completion entry, based on the current Styles.
+func (m *Model) perItemHeight() int {
+	return 1 + max(
+		m.Styles.Item.GetVerticalPadding(),
+		m.Styles.SelectedItem.GetVerticalPadding())
+}
+
+// newCategoryList builds a list.Model for category listIdx configured
+// the way the completions component expects: no built-in title or help,
+// Arabic pagination capped at 4 items per page, and this Model's
+// renderer and key bindings.
+func (m *Model) newCategoryList(listIdx int, items []list.Item) *list.Model {
+	r := &renderer{m: m, listIdx: listIdx}
+	l := list.New(items, r, m.itemWidths[listIdx], stdListHeight)
+	l.Title = "" // Don't use list's built-in title to avoid truncation
+	l.KeyMap = m.KeyMap.KeyMap
+	l.DisableQuitKeybindings()
+	l.SetShowHelp(false)
+	l.SetShowStatusBar(false)
+	// Set the paginator to show up to 4 items per page
+	l.Paginator.PerPage = 4
+	l.Paginator.Type = paginator.Arabic
+	return &l
+}
+
+// CategoryUpdate is a single incremental update pushed by an AsyncValues
+// source, appending entries to one category.
+type CategoryUpdate struct {
+	// CatIdx is the category this update applies to.
+	CatIdx int
+
+	// Entries are appended to whatever has already arrived for CatIdx.
+	Entries []Entry
+
+	// Done reports that CatIdx has received its final entries; its
+	// loading spinner stops once set.
+	Done bool
+}
+
+// AsyncValues is the streaming counterpart to Values, for completion
+// sources that populate slowly (SQL catalog probes, remote schema
+// fetches). Category shape (count and titles) is known up front; the
+// entries within each category arrive incrementally over Updates.
+type AsyncValues interface {
+	// NumCategories returns the number of categories to display.
+	NumCategories() int
+
+	// CategoryTitle returns the title of a category.
+	CategoryTitle(catIdx int) string
+
+	// Updates returns a channel of incremental per-category updates.
+	// Implementations must close the channel once ctx is done or every
+	// category has sent a final update with Done set.
+	Updates(ctx context.Context) <-chan CategoryUpdate
+}
+
+// categoryUpdatedMsg is the internal tea.Msg used to pump AsyncValues
+// updates through Model.Update.
+type categoryUpdatedMsg struct {
+	update CategoryUpdate
+	closed bool
+}
+
+// SetAsyncValues resets the completion list for streaming display: every
+// category starts empty and is populated as av's updates arrive. The
+// returned tea.Cmd must be run by the embedding program (e.g. returned
+// from its own Update) to start the stream. Cancels any values
+// previously set via SetValues or SetAsyncValues, and cancels the
+// stream's context on Abort or the next SetValues/SetAsyncValues call.
+func (m *Model) SetAsyncValues(ctx context.Context, av AsyncValues) tea.Cmd {
+	m.cancelAsync()
+	m.Err = nil
+	m.selectedList = 0
+	m.values = nil
+	m.filtering = false
+	m.matchResults = nil
+	m.searching = false
+	m.searchMatches = nil
+	m.searchPositions = nil
+	m.descExpanded = false
+	m.descCache = nil
+	m.descLastEntry = nil
+	m.markedSet = nil
+	m.markedOrder = nil
+
+	numCats := av.NumCategories()
+	m.valueLists = make([]*list.Model, numCats)
+	m.listItems = make([][]list.Item, numCats)
+	m.categoryNames = make([]string, numCats)
+	m.itemWidths = make([]int, numCats)
+	m.categoryDone = make([]bool, numCats)
+	m.maxHeight = m.listDecorationRows() + 1
+
+	for i := 0; i < numCats; i++ {
+		m.categoryNames[i] = av.CategoryTitle(i)
+		m.itemWidths[i] = 10
+		m.valueLists[i] = m.newCategoryList(i, nil)
+	}
+	m.SetHeight(m.maxHeight)
+
+	wasFocused := m.focused
+	m.Blur()
+	if wasFocused {
+		m.Focus()
+	}
+
+	m.spinner = spinner.New(spinner.WithSpinner(spinner.Dot))
+	m.spinner.Style = m.Styles.Spinner
+
+	ctx, cancel := context.WithCancel(ctx)
+	m.asyncCancel = cancel
+	m.asyncUpdates = av.Updates(ctx)
+
+	return tea.Batch(m.spinner.Tick, m.waitForCategoryUpdate())
+}
+
+// cancelAsync stops any in-flight AsyncValues stream.
+func (m *Model) cancelAsync() {
+	if m.asyncCancel != nil {
+		m.asyncCancel()
+	}
+	m.asyncCancel = nil
+	m.asyncUpdates = nil
+}
+
+// anyCategoryPending reports whether some category is still streaming
+// (and so should keep showing its spinner).
+func (m *Model) anyCategoryPending() bool {
+	for _, done := range m.categoryDone {
+		if !done {
+			return true
+		}
+	}
+	return false
+}
+
+// waitForCategoryUpdate returns a tea.Cmd that blocks on the next
+// AsyncValues update. It must be re-issued after each received update to
+// keep the stream flowing, mirroring the bubbletea channel-listen
+// pattern.
+func (m *Model) waitForCategoryUpdate() tea.Cmd {
+	updates := m.asyncUpdates
+	if updates == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		u, ok := <-updates
+		if !ok {
+			return categoryUpdatedMsg{closed: true}
+		}
+		return categoryUpdatedMsg{update: u}
+	}
+}
+
+// applyCategoryUpdate appends u's entries to their category, updates
+// that category's rendered width and the overall height, and re-applies
+// the active filter (if any).
+func (m *Model) applyCategoryUpdate(u CategoryUpdate) {
+	if u.CatIdx < 0 || u.CatIdx >= len(m.valueLists) {
+		return
+	}
+	for _, e := range u.Entries {
+		entryIdx := len(m.listItems[u.CatIdx])
+		m.listItems[u.CatIdx] = append(m.listItems[u.CatIdx], candidateItem{Entry: e, catIdx: u.CatIdx, entryIdx: entryIdx})
+		if w := rw.StringWidth(e.Title()); w > m.itemWidths[u.CatIdx] {
+			m.itemWidths[u.CatIdx] = w
+		}
+	}
+	if u.Done {
+		m.categoryDone[u.CatIdx] = true
+	}
+
+	if m.filtering {
+		m.applyFilter()
+	} else {
+		m.valueLists[u.CatIdx].SetItems(m.listItems[u.CatIdx])
+	}
+
+	itemsToShow := min(len(m.listItems[u.CatIdx]), 5)
+	if h := itemsToShow*m.perItemHeight() + m.listDecorationRows() + 1; h > m.maxHeight {
+		m.maxHeight = h
+		m.SetHeight(m.maxHeight)
+	}
+}
+
 // MatchesKeys returns true when the completion
 // editor can use the given key message.
 func (m *Model) MatchesKey(msg tea.KeyMsg) bool {
 	if !m.focused || len(m.valueLists) == 0 {
 		return false
 	}
+	if m.filtering || m.searching {
+		return true
+	}
 
 	curList := m.valueLists[m.selectedList]
 	switch {
@@ -358,7 +975,16 @@ func (m *Model) MatchesKey(msg tea.KeyMsg) bool {
 		m.KeyMap.NextCompletions,
 		m.KeyMap.NextPage,
 		m.KeyMap.PrevPage,
-		m.KeyMap.Abort):
+		m.KeyMap.Abort,
+		m.KeyMap.ToggleDescription,
+		m.KeyMap.DescUp,
+		m.KeyMap.DescDown,
+		m.KeyMap.ToggleMark,
+		m.KeyMap.MarkAll,
+		m.KeyMap.UnmarkAll,
+		m.KeyMap.Search,
+		m.KeyMap.SearchNext,
+		m.KeyMap.SearchPrev):
 		return true
 	case !curList.SettingFilter() &&
 		key.Matches(msg, m.KeyMap.AcceptCompletion):
@@ -410,6 +1036,113 @@ func (m *Model) nextCompletions() {
 	}
 }
 
+// startFiltering enters filtering mode: a text input is focused and
+// every keystroke re-ranks candidates via m.Matcher.
+func (m *Model) startFiltering() {
+	m.filtering = true
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.PromptStyle = m.Styles.FilterPrompt
+	ti.Cursor.Style = m.Styles.FilterCursor
+	ti.Focus()
+	m.filterInput = ti
+	m.applyFilter()
+}
+
+// cancelFiltering leaves filtering mode and restores the unfiltered,
+// original-order candidates.
+func (m *Model) cancelFiltering() {
+	m.filtering = false
+	m.filterInput.Blur()
+	m.matchResults = nil
+	for i, l := range m.valueLists {
+		l.SetItems(m.listItems[i])
+	}
+}
+
+// stopFiltering leaves filtering mode but keeps the current filtered
+// results and ranking in place.
+func (m *Model) stopFiltering() {
+	m.filtering = false
+	m.filterInput.Blur()
+}
+
+// applyFilter re-ranks every category's candidates against the current
+// filter query using m.Matcher, preserving category grouping.
+func (m *Model) applyFilter() {
+	if m.Matcher == nil {
+		m.Matcher = NewFuzzyMatcher()
+	}
+	query := m.filterInput.Value()
+	m.matchResults = make([][]MatchResult, len(m.valueLists))
+	for catIdx, l := range m.valueLists {
+		items := m.listItems[catIdx]
+		type scored struct {
+			idx int
+			mr  MatchResult
+		}
+		matches := make([]scored, 0, len(items))
+		for idx, it := range items {
+			mr, ok := m.Matcher.Match(query, it.(candidateItem).Title())
+			if !ok {
+				continue
+			}
+			matches = append(matches, scored{idx, mr})
+		}
+		sort.SliceStable(matches, func(a, b int) bool {
+			return m.lessMatch(matches[a].mr, matches[a].idx, matches[b].mr, matches[b].idx)
+		})
+
+		filtered := make([]list.Item, len(matches))
+		mrs := make([]MatchResult, len(matches))
+		for j, s := range matches {
+			filtered[j] = items[s.idx]
+			mrs[j] = s.mr
+		}
+		l.SetItems(filtered)
+		m.matchResults[catIdx] = mrs
+	}
+}
+
+// lessMatch orders two matches by score (best first), falling back to
+// m.Tiebreak to resolve ties.
+func (m *Model) lessMatch(a MatchResult, aIdx int, b MatchResult, bIdx int) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	for _, tb := range m.Tiebreak {
+		switch tb {
+		case "length":
+			if la, lb := matchSpan(a), matchSpan(b); la != lb {
+				return la < lb
+			}
+		case "start":
+			if sa, sb := matchStart(a), matchStart(b); sa != sb {
+				return sa < sb
+			}
+		case "index":
+			if aIdx != bIdx {
+				return aIdx < bIdx
+			}
+		}
+	}
+	return aIdx < bIdx
+}
+
+func matchStart(mr MatchResult) int {
+	if len(mr.Positions) == 0 {
+		return 0
+	}
+	return mr.Positions[0]
+}
+
+func matchSpan(mr MatchResult) int {
+	if len(mr.Positions) == 0 {
+		return 0
+	}
+	return mr.Positions[len(mr.Positions)-1] - mr.Positions[0] + 1
+}
+
 // Init implements the tea.Model interface.
 func (m *Model) Init() tea.Cmd {
 	return nil
@@ -417,19 +1150,118 @@ func (m *Model) Init() tea.Cmd {
 
 // Update implements the tea.Model interface.
 func (m *Model) Update(imsg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := imsg.(type) {
+	case categoryUpdatedMsg:
+		if msg.closed {
+			m.asyncUpdates = nil
+			// The stream ended (e.g. context cancellation) without
+			// every category sending a final Done update; mark them
+			// all done so their spinners stop instead of ticking
+			// forever with nothing left to drive them.
+			for i := range m.categoryDone {
+				m.categoryDone[i] = true
+			}
+			return m, nil
+		}
+		m.applyCategoryUpdate(msg.update)
+		return m, m.waitForCategoryUpdate()
+	case spinner.TickMsg:
+		if !m.anyCategoryPending() {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
 	if len(m.valueLists) == 0 {
 		m.Err = io.EOF
 		return m, nil
 	}
 
 	curList := m.valueLists[m.selectedList]
+	defer func() {
+		if m.descExpanded {
+			m.syncDescViewport()
+		}
+	}()
+
+	if m.filtering {
+		if msg, ok := imsg.(tea.KeyMsg); ok {
+			switch {
+			case key.Matches(msg, m.KeyMap.CancelWhileFiltering, m.KeyMap.Abort):
+				m.cancelFiltering()
+				return m, nil
+			case key.Matches(msg, m.KeyMap.AcceptWhileFiltering):
+				m.stopFiltering()
+				m.acceptSelection(curList)
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.filterInput, cmd = m.filterInput.Update(msg)
+			m.applyFilter()
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	if m.searching {
+		if msg, ok := imsg.(tea.KeyMsg); ok {
+			switch {
+			case key.Matches(msg, m.KeyMap.Abort):
+				m.cancelSearch()
+				return m, nil
+			case key.Matches(msg, m.KeyMap.AcceptCompletion):
+				m.stopSearch()
+				m.acceptSelection(curList)
+				return m, nil
+			case key.Matches(msg, m.KeyMap.SearchNext):
+				m.searchNext()
+				return m, nil
+			case key.Matches(msg, m.KeyMap.SearchPrev):
+				m.searchPrev()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.searchInput, cmd = m.searchInput.Update(msg)
+			m.applySearch()
+			return m, cmd
+		}
+		return m, nil
+	}
+
 	switch msg := imsg.(type) {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, m.KeyMap.Abort):
+			m.cancelAsync()
 			m.AcceptedValue = nil
 			m.Err = io.EOF
 			imsg = nil
+		case key.Matches(msg, m.KeyMap.Filter):
+			m.startFiltering()
+			imsg = nil
+		case key.Matches(msg, m.KeyMap.Search):
+			m.startSearch()
+			imsg = nil
+		case key.Matches(msg, m.KeyMap.ToggleDescription):
+			m.toggleDescription()
+			imsg = nil
+		case m.descExpanded && key.Matches(msg, m.KeyMap.DescDown):
+			m.descViewport.LineDown(1)
+			imsg = nil
+		case m.descExpanded && key.Matches(msg, m.KeyMap.DescUp):
+			m.descViewport.LineUp(1)
+			imsg = nil
+		case key.Matches(msg, m.KeyMap.ToggleMark):
+			m.toggleMark(curList)
+			imsg = nil
+		case key.Matches(msg, m.KeyMap.MarkAll):
+			m.markAll()
+			imsg = nil
+		case key.Matches(msg, m.KeyMap.UnmarkAll):
+			m.unmarkAll()
+			imsg = nil
 		case !curList.SettingFilter():
 			switch {
 			case key.Matches(msg, m.KeyMap.PrevCompletions):
@@ -449,9 +1281,7 @@ func (m *Model) Update(imsg tea.Msg) (tea.Model, tea.Cmd) {
 					imsg = nil
 				}
 			case key.Matches(msg, m.KeyMap.AcceptCompletion):
-				v := curList.SelectedItem().(candidateItem)
-				m.AcceptedValue = v.Entry
-				m.Err = io.EOF
+				m.acceptSelection(curList)
 				imsg = nil
 			}
 		}
@@ -468,10 +1298,14 @@ func (m *Model) Update(imsg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.width >= 10 {
 		newModel.SetWidth(m.width)
 	}
+	m.valueLists[m.selectedList] = &newModel
 	if m.height >= 2 {
-		newModel.SetHeight(m.height - 1)
+		// Route through SetHeight rather than poking newModel
+		// directly, so the description-viewport split is respected
+		// when descExpanded: setting the list's height to m.height-1
+		// unconditionally re-inflated it past the viewport.
+		m.SetHeight(m.height)
 	}
-	m.valueLists[m.selectedList] = &newModel
 	return m, cmd
 }
 
@@ -490,15 +1324,32 @@ func (m *Model) View() string {
 			titleStyle = m.Styles.FocusedTitle
 		}
 		title := titleStyle.Render(m.categoryNames[i])
+		if i < len(m.categoryDone) && !m.categoryDone[i] {
+			title = m.spinner.View() + " " + title
+		}
+		if m.multiSelectMax != 0 {
+			title += titleStyle.Render(fmt.Sprintf(" %d/%d", m.markedCount(i), len(m.listItems[i])))
+		}
 		contents[i] = title + l.View()
 	}
 	result := lipgloss.JoinHorizontal(lipgloss.Top, contents...)
 
 	curSelected := m.valueLists[m.selectedList].SelectedItem()
 	var desc string
-	if curSelected == nil {
+	switch {
+	case m.descExpanded:
+		desc = m.descViewport.View()
+	case m.searching:
+		pos := 0
+		if len(m.searchMatches) > 0 {
+			pos = m.searchCurrent + 1
+		}
+		desc = fmt.Sprintf("search: %s  [%d/%d]", m.searchInput.View(), pos, len(m.searchMatches))
+	case m.filtering:
+		desc = m.filterInput.View()
+	case curSelected == nil:
 		desc = m.Styles.PlaceholderDescription.Render("(no entry seleted)")
-	} else {
+	default:
 		item := curSelected.(candidateItem)
 		desc = item.Description()
 		if desc != "" {
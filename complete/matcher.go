@@ -0,0 +1,203 @@
+package complete
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// MatchResult describes how a query matched a candidate string.
+type MatchResult struct {
+	// Score is the match quality; higher is better. A zero-value
+	// MatchResult (no Positions, Score 0) represents "no filter
+	// currently applied".
+	Score int
+
+	// Positions are the byte offsets into the candidate of the runes
+	// that matched the query, in ascending order. Used by the renderer
+	// to highlight matched characters via Styles.DefaultFilterCharacterMatch.
+	Positions []int
+}
+
+// Matcher is the pluggable algorithm used to fuzzy-match a query against
+// completion candidates. Model.SetMatcher lets callers swap in
+// exact-match, prefix-only, or custom scoring in place of the default
+// fzf-inspired matcher.
+type Matcher interface {
+	// Match reports whether query matches candidate and, if so, a
+	// MatchResult describing the quality and location of the match. An
+	// empty query always matches with a zero-value MatchResult.
+	Match(query, candidate string) (MatchResult, bool)
+}
+
+// MatcherFunc adapts a plain function to the Matcher interface.
+type MatcherFunc func(query, candidate string) (MatchResult, bool)
+
+// Match implements Matcher.
+func (f MatcherFunc) Match(query, candidate string) (MatchResult, bool) {
+	return f(query, candidate)
+}
+
+// Bonus/penalty weights, tuned to mirror the shape of fzf's v2 scoring:
+// consecutive runs and boundary starts are rewarded, gaps between
+// matched runes are penalized in proportion to their length.
+const (
+	scorePerMatch      = 16
+	bonusBoundary      = 10
+	bonusBoundaryFirst = 2 // multiplier applied to bonusBoundary for position 0
+	bonusConsecutive   = 8
+	penaltyGapStart    = 3
+	penaltyGapExtend   = 1
+)
+
+// FuzzyMatcher is the default Matcher. It finds the leftmost occurrence
+// of query as a subsequence of candidate, tightens it towards the end of
+// that occurrence (favoring dense, late matches the way fzf's v2
+// algorithm does), and scores the result by rewarding consecutive runs,
+// word-boundary/camelCase starts, and prefix matches while penalizing
+// gaps between matched runes.
+type FuzzyMatcher struct {
+	// SmartCase makes matching case-insensitive unless query contains an
+	// uppercase rune, in which case matching becomes case-sensitive.
+	SmartCase bool
+}
+
+var _ Matcher = (*FuzzyMatcher)(nil)
+
+// NewFuzzyMatcher returns the default FuzzyMatcher, with smart-case
+// enabled.
+func NewFuzzyMatcher() *FuzzyMatcher {
+	return &FuzzyMatcher{SmartCase: true}
+}
+
+// Match implements Matcher.
+func (fm *FuzzyMatcher) Match(query, candidate string) (MatchResult, bool) {
+	if query == "" {
+		return MatchResult{}, true
+	}
+
+	caseSensitive := fm.SmartCase && hasUpper(query)
+	q := []rune(query)
+	c := []rune(candidate)
+	cFold := c
+	if !caseSensitive {
+		q = toLowerRunes(q)
+		cFold = toLowerRunes(c)
+	}
+
+	positions, ok := subsequenceMatch(q, cFold)
+	if !ok {
+		return MatchResult{}, false
+	}
+
+	score := scoreMatch(c, positions)
+	return MatchResult{Score: score, Positions: runePositionsToByteOffsets(candidate, positions)}, true
+}
+
+// subsequenceMatch finds the leftmost occurrence of q as a subsequence
+// of c, then tightens it by re-matching backwards from the end of that
+// occurrence. The backward pass pulls matched runes as close together
+// (and as close to the leftmost occurrence's end) as possible, which is
+// what tends to score best.
+func subsequenceMatch(q, c []rune) ([]int, bool) {
+	if len(q) == 0 {
+		return nil, true
+	}
+
+	qi := 0
+	end := -1
+	for i, r := range c {
+		if qi < len(q) && r == q[qi] {
+			qi++
+			if qi == len(q) {
+				end = i
+				break
+			}
+		}
+	}
+	if end == -1 {
+		return nil, false
+	}
+
+	positions := make([]int, len(q))
+	qi = len(q) - 1
+	for i := end; i >= 0 && qi >= 0; i-- {
+		if c[i] == q[qi] {
+			positions[qi] = i
+			qi--
+		}
+	}
+	return positions, true
+}
+
+// scoreMatch scores a set of matched rune positions (in the original,
+// non-case-folded candidate) using fzf-style bonuses and penalties.
+func scoreMatch(c []rune, positions []int) int {
+	score := 0
+	for i, pos := range positions {
+		score += scorePerMatch
+		switch {
+		case pos == 0:
+			score += bonusBoundary * bonusBoundaryFirst
+		case isWordBoundary(c[pos-1], c[pos]):
+			score += bonusBoundary
+		}
+		if i == 0 {
+			continue
+		}
+		if gap := pos - positions[i-1] - 1; gap == 0 {
+			score += bonusConsecutive
+		} else {
+			score -= penaltyGapStart + gap*penaltyGapExtend
+		}
+	}
+	return score
+}
+
+// isWordBoundary reports whether cur begins a new "word" in candidate,
+// i.e. prev is a separator or cur is an uppercase letter following a
+// lowercase one (camelCase).
+func isWordBoundary(prev, cur rune) bool {
+	switch prev {
+	case '_', '-', '/', '.', ' ':
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
+
+func hasUpper(s string) bool {
+	for _, r := range s {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func toLowerRunes(rs []rune) []rune {
+	out := make([]rune, len(rs))
+	for i, r := range rs {
+		out[i] = unicode.ToLower(r)
+	}
+	return out
+}
+
+// runePositionsToByteOffsets converts rune indices into s to byte
+// offsets, so Positions can be used directly to slice s.
+func runePositionsToByteOffsets(s string, runePositions []int) []int {
+	if len(runePositions) == 0 {
+		return nil
+	}
+	offsets := make([]int, len(runePositions))
+	pi, runeIdx, byteIdx := 0, 0, 0
+	for pi < len(runePositions) {
+		if runeIdx == runePositions[pi] {
+			offsets[pi] = byteIdx
+			pi++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[byteIdx:])
+		byteIdx += size
+		runeIdx++
+	}
+	return offsets
+}
@@ -0,0 +1,163 @@
+package complete
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsWordBoundary(t *testing.T) {
+	tests := []struct {
+		name      string
+		prev, cur rune
+		want      bool
+	}{
+		{"underscore separator", '_', 'b', true},
+		{"hyphen separator", '-', 'b', true},
+		{"slash separator", '/', 'b', true},
+		{"dot separator", '.', 'b', true},
+		{"space separator", ' ', 'b', true},
+		{"camelCase transition", 'o', 'B', true},
+		{"no transition, both lower", 'o', 'o', false},
+		{"no transition, both upper", 'O', 'B', false},
+		{"upper then lower isn't a boundary", 'O', 'b', false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isWordBoundary(tt.prev, tt.cur); got != tt.want {
+				t.Errorf("isWordBoundary(%q, %q) = %v, want %v", tt.prev, tt.cur, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubsequenceMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		q    string
+		c    string
+		want []int
+		ok   bool
+	}{
+		{"empty query always matches with no positions", "", "anything", nil, true},
+		{"no match", "xyz", "abc", nil, false},
+		{"already-tight match needs no tightening", "ab", "xaxb", []int{1, 3}, true},
+		{
+			// The leftmost forward pass would pick 'a' at index 0, but
+			// backtracking from the leftmost occurrence's end should
+			// pull it up to the tighter, later 'a' at index 3.
+			"backward pass tightens towards the end of the leftmost occurrence",
+			"ab", "aXXabX", []int{3, 4}, true,
+		},
+		{"full-string match", "abc", "abc", []int{0, 1, 2}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := subsequenceMatch([]rune(tt.q), []rune(tt.c))
+			if ok != tt.ok {
+				t.Fatalf("subsequenceMatch(%q, %q) ok = %v, want %v", tt.q, tt.c, ok, tt.ok)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("subsequenceMatch(%q, %q) = %v, want %v", tt.q, tt.c, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		candidate string
+		positions []int
+		want      int
+	}{
+		{
+			"match at position 0 gets the boundary-first bonus",
+			"abcd", []int{0},
+			scorePerMatch + bonusBoundary*bonusBoundaryFirst,
+		},
+		{
+			"match after a separator gets the plain boundary bonus",
+			"foo_bar", []int{4}, // 'b', right after '_'
+			scorePerMatch + bonusBoundary,
+		},
+		{
+			"camelCase transition gets the boundary bonus",
+			"FooBar", []int{3}, // 'B', after lowercase 'o'
+			scorePerMatch + bonusBoundary,
+		},
+		{
+			"mid-word match gets no boundary bonus",
+			"abcd", []int{2}, // 'c', after 'b'
+			scorePerMatch,
+		},
+		{
+			"consecutive matches are rewarded",
+			"foo_bar", []int{4, 5}, // "ba", both right after '_'
+			(scorePerMatch + bonusBoundary) + (scorePerMatch + bonusConsecutive),
+		},
+		{
+			"gaps between matches are penalized proportionally to their length",
+			"abcd", []int{0, 3}, // gap of 2 runes between 'a' and 'd'
+			(scorePerMatch + bonusBoundary*bonusBoundaryFirst) + (scorePerMatch - (penaltyGapStart + 2*penaltyGapExtend)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scoreMatch([]rune(tt.candidate), tt.positions)
+			if got != tt.want {
+				t.Errorf("scoreMatch(%q, %v) = %d, want %d", tt.candidate, tt.positions, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatcher_SmartCase(t *testing.T) {
+	tests := []struct {
+		name      string
+		smartCase bool
+		query     string
+		candidate string
+		wantOK    bool
+	}{
+		{"smart-case: lowercase query matches any case", true, "foo", "FooBar", true},
+		{"smart-case: uppercase query forces case-sensitive, mismatched case fails", true, "Foo", "foobar", false},
+		{"smart-case: uppercase query forces case-sensitive, matching case succeeds", true, "Foo", "FooBar", true},
+		{"case-insensitive mode ignores query case entirely", false, "FOO", "foobar", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm := &FuzzyMatcher{SmartCase: tt.smartCase}
+			_, ok := fm.Match(tt.query, tt.candidate)
+			if ok != tt.wantOK {
+				t.Errorf("Match(%q, %q) with SmartCase=%v: ok = %v, want %v", tt.query, tt.candidate, tt.smartCase, ok, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatcher_MultibyteHighlightOffsets(t *testing.T) {
+	// "héllo": h(1 byte) é(2 bytes) l(1) l(1) o(1). Matching "hl" should
+	// land on the first 'h' and the first 'l', which starts 3 bytes in
+	// (not 2, which is what a naive one-byte-per-rune assumption would
+	// produce).
+	fm := NewFuzzyMatcher()
+	mr, ok := fm.Match("hl", "héllo")
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	want := []int{0, 3}
+	if !reflect.DeepEqual(mr.Positions, want) {
+		t.Errorf("Positions = %v, want %v", mr.Positions, want)
+	}
+}
+
+func TestFuzzyMatcher_EmptyQueryMatchesWithZeroResult(t *testing.T) {
+	fm := NewFuzzyMatcher()
+	mr, ok := fm.Match("", "anything")
+	if !ok {
+		t.Fatal("expected an empty query to always match")
+	}
+	if mr.Score != 0 || len(mr.Positions) != 0 {
+		t.Errorf("expected zero-value MatchResult, got %+v", mr)
+	}
+}
@@ -0,0 +1,176 @@
+package complete
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// Searcher is the match function used by the in-list search (ctrl+s),
+// distinct from Matcher's fuzzy filter: searching never removes
+// non-matching entries, only highlights them. It reports whether query
+// matches candidate and, if so, the byte offsets to highlight via
+// Styles.DefaultFilterCharacterMatch.
+type Searcher func(query, candidate string) (positions []int, ok bool)
+
+// DefaultSearcher is the default Searcher: a case-insensitive substring
+// match against candidate.
+func DefaultSearcher(query, candidate string) ([]int, bool) {
+	if query == "" {
+		return nil, false
+	}
+	q := toLowerRunes([]rune(query))
+	c := toLowerRunes([]rune(candidate))
+
+	start := indexRunes(c, q)
+	if start == -1 {
+		return nil, false
+	}
+
+	runePositions := make([]int, len(q))
+	for i := range runePositions {
+		runePositions[i] = start + i
+	}
+	return runePositionsToByteOffsets(candidate, runePositions), true
+}
+
+// indexRunes returns the index of the first occurrence of sub within s,
+// or -1 if sub doesn't occur in s. Matching rune-by-rune (rather than
+// byte-by-byte, as strings.Index would) keeps it correct when
+// case-folding changes a rune's UTF-8 length.
+func indexRunes(s, sub []rune) int {
+	if len(sub) == 0 {
+		return 0
+	}
+	for i := 0; i+len(sub) <= len(s); i++ {
+		match := true
+		for j, r := range sub {
+			if s[i+j] != r {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// startSearch enters in-list search mode: a text input anchored in the
+// description slot is focused, and every keystroke re-highlights
+// matches across every category without removing non-matching entries.
+// The current selection is remembered so Escape can restore it.
+func (m *Model) startSearch() {
+	m.searching = true
+	m.searchRestoreList = m.selectedList
+	m.searchRestoreSel = make([]int, len(m.valueLists))
+	for i, l := range m.valueLists {
+		m.searchRestoreSel[i] = l.Index()
+	}
+
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.PromptStyle = m.Styles.FilterPrompt
+	ti.Cursor.Style = m.Styles.FilterCursor
+	ti.Focus()
+	m.searchInput = ti
+	m.applySearch()
+}
+
+// cancelSearch leaves search mode and restores the selection as it was
+// before searching started.
+func (m *Model) cancelSearch() {
+	m.searching = false
+	m.searchInput.Blur()
+	m.searchMatches = nil
+	m.searchPositions = nil
+
+	wasFocused := m.focused
+	m.Blur()
+	m.selectedList = m.searchRestoreList
+	for i, l := range m.valueLists {
+		if i < len(m.searchRestoreSel) {
+			l.Select(m.searchRestoreSel[i])
+		}
+	}
+	if wasFocused {
+		m.Focus()
+	}
+}
+
+// stopSearch leaves search mode but keeps the current highlight and
+// selection in place, e.g. when accepting the highlighted entry.
+func (m *Model) stopSearch() {
+	m.searching = false
+	m.searchInput.Blur()
+}
+
+// applySearch recomputes, across every category in their stable
+// (unfiltered) order, which entries match the current search query.
+func (m *Model) applySearch() {
+	searcher := m.Searcher
+	if searcher == nil {
+		searcher = DefaultSearcher
+	}
+	query := m.searchInput.Value()
+
+	m.searchMatches = nil
+	m.searchPositions = make(map[markKey][]int)
+	for catIdx, items := range m.listItems {
+		for _, it := range items {
+			ci := it.(candidateItem)
+			positions, ok := searcher(query, ci.Title())
+			if !ok {
+				continue
+			}
+			k := markKey{catIdx: catIdx, entryIdx: ci.entryIdx}
+			m.searchMatches = append(m.searchMatches, k)
+			m.searchPositions[k] = positions
+		}
+	}
+	m.gotoSearchMatch(0)
+}
+
+// gotoSearchMatch moves the selection to the idx'th search match,
+// wrapping around the ends of m.searchMatches.
+func (m *Model) gotoSearchMatch(idx int) {
+	if len(m.searchMatches) == 0 {
+		m.searchCurrent = 0
+		return
+	}
+	idx = ((idx % len(m.searchMatches)) + len(m.searchMatches)) % len(m.searchMatches)
+	m.searchCurrent = idx
+	k := m.searchMatches[idx]
+
+	wasFocused := m.focused
+	m.Blur()
+	m.selectedList = k.catIdx
+	m.selectDisplayedEntry(k.catIdx, k.entryIdx)
+	if wasFocused {
+		m.Focus()
+	}
+}
+
+// selectDisplayedEntry selects whichever row of category catIdx's list
+// currently displays entryIdx, so the highlight survives pagination and
+// any active filter re-ordering the displayed rows.
+func (m *Model) selectDisplayedEntry(catIdx, entryIdx int) {
+	l := m.valueLists[catIdx]
+	for i, it := range l.Items() {
+		if ci, ok := it.(candidateItem); ok && ci.entryIdx == entryIdx {
+			l.Select(i)
+			return
+		}
+	}
+}
+
+// searchNext moves to the next search match, across categories and
+// wrapping at the end.
+func (m *Model) searchNext() {
+	m.gotoSearchMatch(m.searchCurrent + 1)
+}
+
+// searchPrev moves to the previous search match, across categories and
+// wrapping at the start.
+func (m *Model) searchPrev() {
+	m.gotoSearchMatch(m.searchCurrent - 1)
+}